@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"sync"
+
+	"golang.org/x/image/font"
+)
+
+// imageCache and fontCache hold every decoded asset image and parsed font
+// face the server has seen, so createPortraitHandler never touches disk
+// after startup. They're populated up front by preloadAssets and filled in
+// on demand for anything that wasn't anticipated (e.g. a font size requested
+// via a non-default `size`).
+var (
+	imageCache sync.Map // map[string]image.Image
+	fontCache  sync.Map // map[float64]font.Face
+)
+
+// preloadAssets decodes every character, gear, badge and relic PNG referenced
+// by reg, and parses the Inter font at the base layout's two sizes. It must
+// run once at startup, before the server accepts requests.
+func preloadAssets(reg *CharacterRegistry) error {
+	affiliations := make(map[string]struct{})
+	for _, char := range reg.All() {
+		// A missing character asset falls back to an initials avatar at
+		// request time, so a cache miss here isn't fatal.
+		_, _ = cachedImage("assets/characters/" + char.ImgSrc)
+		affiliations[char.Affiliation] = struct{}{}
+	}
+
+	for gear := 1; gear <= 12; gear++ {
+		if _, err := cachedImage(fmt.Sprintf("assets/gear/%d.png", gear)); err != nil {
+			return err
+		}
+	}
+
+	for affiliation := range affiliations {
+		if _, err := cachedImage("assets/relics/" + affiliation + ".png"); err != nil {
+			return err
+		}
+	}
+
+	for _, badge := range []string{"level", "zeta", "omicron"} {
+		if _, err := cachedImage("assets/badges/" + badge + ".png"); err != nil {
+			return err
+		}
+	}
+
+	baseLayout := NewLayout(baseCanvasSize)
+	if _, err := cachedFont(baseLayout.SmallFontSize); err != nil {
+		return err
+	}
+	if _, err := cachedFont(baseLayout.LargeFontSize); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// cachedImage returns the decoded image at path, decoding and caching it on
+// first use.
+func cachedImage(path string) (image.Image, error) {
+	if img, ok := imageCache.Load(path); ok {
+		return img.(image.Image), nil
+	}
+	img, err := loadImage(path)
+	if err != nil {
+		return nil, err
+	}
+	imageCache.Store(path, img)
+	return img, nil
+}
+
+// cachedFont returns the Inter font face at size, parsing and caching it on
+// first use.
+func cachedFont(size float64) (font.Face, error) {
+	if face, ok := fontCache.Load(size); ok {
+		return face.(font.Face), nil
+	}
+	face, err := loadFont("assets/fonts/Inter-Regular.ttf", size)
+	if err != nil {
+		return nil, err
+	}
+	fontCache.Store(size, face)
+	return face, nil
+}
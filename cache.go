@@ -0,0 +1,64 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// PortraitCache is a two-tier cache for encoded portrait bytes: a bounded
+// in-memory LRU backed by an on-disk directory keyed by the same canonical
+// hash, so a freshly started process can still serve previously rendered
+// portraits without recomposing them from assets.
+type PortraitCache struct {
+	memory *lru.Cache
+	dir    string
+}
+
+// NewPortraitCache creates a cache holding up to capacity entries in memory,
+// persisted under dir on disk.
+func NewPortraitCache(capacity int, dir string) (*PortraitCache, error) {
+	memory, err := lru.New(capacity)
+	if err != nil {
+		return nil, fmt.Errorf("portrait cache: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("portrait cache: failed to create cache dir %q: %w", dir, err)
+	}
+	return &PortraitCache{memory: memory, dir: dir}, nil
+}
+
+// CacheKey returns the canonical cache key for a rendered portrait request,
+// hashing every parameter that affects the output bytes. quality only
+// affects lossy formats (JPEG/WebP), but is always included since it's
+// cheap and avoids having to special-case it per format.
+func CacheKey(charID string, gearLevel, relicLevel, zetas, omicrons, level, size int, format OutputFormat, quality int) string {
+	raw := fmt.Sprintf("%s|%d|%d|%d|%d|%d|%d|%s|%d", charID, gearLevel, relicLevel, zetas, omicrons, level, size, format, quality)
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// Get returns the cached bytes for key, checking memory first and falling
+// back to disk. A disk hit is promoted back into the memory tier.
+func (c *PortraitCache) Get(key string) ([]byte, bool) {
+	if v, ok := c.memory.Get(key); ok {
+		return v.([]byte), true
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, key))
+	if err != nil {
+		return nil, false
+	}
+	c.memory.Add(key, data)
+	return data, true
+}
+
+// Put stores data under key in both the memory and disk tiers.
+func (c *PortraitCache) Put(key string, data []byte) error {
+	c.memory.Add(key, data)
+	return os.WriteFile(filepath.Join(c.dir, key), data, 0o644)
+}
@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+func TestCacheKey_Deterministic(t *testing.T) {
+	a := CacheKey("REY", 12, 0, 1, 0, 85, 200, FormatPNG, 90)
+	b := CacheKey("REY", 12, 0, 1, 0, 85, 200, FormatPNG, 90)
+	if a != b {
+		t.Fatalf("expected the same inputs to produce the same key, got %q vs %q", a, b)
+	}
+}
+
+func TestCacheKey_QualityAffectsKey(t *testing.T) {
+	a := CacheKey("REY", 12, 0, 1, 0, 85, 200, FormatJPEG, 30)
+	b := CacheKey("REY", 12, 0, 1, 0, 85, 200, FormatJPEG, 95)
+	if a == b {
+		t.Fatalf("expected different quality values to produce different keys, both were %q", a)
+	}
+}
+
+func TestCacheKey_LevelAffectsKey(t *testing.T) {
+	a := CacheKey("REY", 12, 0, 1, 0, 50, 200, FormatPNG, 90)
+	b := CacheKey("REY", 12, 0, 1, 0, 85, 200, FormatPNG, 90)
+	if a == b {
+		t.Fatalf("expected different level values to produce different keys, both were %q", a)
+	}
+}
+
+func TestCacheKey_FormatAffectsKey(t *testing.T) {
+	a := CacheKey("REY", 12, 0, 1, 0, 85, 200, FormatPNG, 90)
+	b := CacheKey("REY", 12, 0, 1, 0, 85, 200, FormatWebP, 90)
+	if a == b {
+		t.Fatalf("expected different formats to produce different keys, both were %q", a)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Character describes a single roster entry as defined in the character
+// manifest. Fields are exported so the JSON/YAML manifest and the
+// /characters API can marshal them directly.
+type Character struct {
+	ID           string   `json:"id" yaml:"id"`
+	Name         string   `json:"name" yaml:"name"`
+	Affiliation  string   `json:"affiliation" yaml:"affiliation"`
+	ImgSrc       string   `json:"img_src" yaml:"img_src"`
+	MaxZetas     int      `json:"max_zetas" yaml:"max_zetas"`
+	MaxOmicrons  int      `json:"max_omicrons" yaml:"max_omicrons"`
+	MaxDatacrons int      `json:"max_datacrons" yaml:"max_datacrons"`
+	Alignment    string   `json:"alignment" yaml:"alignment"`
+	AbilityNames []string `json:"ability_names" yaml:"ability_names"`
+}
+
+// characterManifest is the on-disk shape of the JSON/YAML roster file.
+type characterManifest struct {
+	Characters []Character `json:"characters" yaml:"characters"`
+}
+
+// CharacterRegistry holds the roster of supported characters, loaded from a
+// manifest file on disk. It is safe for concurrent use, and Reload can be
+// called at any time (e.g. in response to SIGHUP) to pick up manifest edits
+// without restarting the server.
+type CharacterRegistry struct {
+	mu         sync.RWMutex
+	source     string
+	characters map[string]Character
+}
+
+// NewCharacterRegistry loads the manifest at source (a .json, .yaml or .yml
+// file) and returns a registry backed by it. The initial load must succeed.
+func NewCharacterRegistry(source string) (*CharacterRegistry, error) {
+	reg := &CharacterRegistry{source: source}
+	if err := reg.Reload(); err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
+
+// Reload re-reads and re-parses the manifest, replacing the registry's
+// contents atomically on success. On failure the previously loaded roster is
+// left untouched.
+func (r *CharacterRegistry) Reload() error {
+	data, err := os.ReadFile(r.source)
+	if err != nil {
+		return fmt.Errorf("character registry: failed to read manifest %q: %w", r.source, err)
+	}
+
+	var manifest characterManifest
+	switch ext := filepath.Ext(r.source); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &manifest); err != nil {
+			return fmt.Errorf("character registry: invalid YAML in %q: %w", r.source, err)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return fmt.Errorf("character registry: invalid JSON in %q: %w", r.source, err)
+		}
+	default:
+		return fmt.Errorf("character registry: unsupported manifest extension %q in %q", ext, r.source)
+	}
+
+	characters := make(map[string]Character, len(manifest.Characters))
+	for i, c := range manifest.Characters {
+		if c.ID == "" {
+			return fmt.Errorf("character registry: entry %d in %q is missing an 'id'", i, r.source)
+		}
+		if _, exists := characters[c.ID]; exists {
+			return fmt.Errorf("character registry: duplicate character id %q in %q", c.ID, r.source)
+		}
+		characters[c.ID] = c
+	}
+
+	r.mu.Lock()
+	r.characters = characters
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Get returns the character with the given id, if supported.
+func (r *CharacterRegistry) Get(id string) (Character, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.characters[id]
+	return c, ok
+}
+
+// All returns every character currently loaded, sorted by nothing in
+// particular; callers that need a stable order should sort the result.
+func (r *CharacterRegistry) All() []Character {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	all := make([]Character, 0, len(r.characters))
+	for _, c := range r.characters {
+		all = append(all, c)
+	}
+	return all
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeManifest(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	return path
+}
+
+func TestNewCharacterRegistry_JSON(t *testing.T) {
+	dir := t.TempDir()
+	path := writeManifest(t, dir, "characters.json", `{"characters":[{"id":"DARTHVADER","name":"Darth Vader","max_zetas":3,"max_omicrons":1}]}`)
+
+	reg, err := NewCharacterRegistry(path)
+	if err != nil {
+		t.Fatalf("NewCharacterRegistry: %v", err)
+	}
+
+	char, ok := reg.Get("DARTHVADER")
+	if !ok {
+		t.Fatalf("expected DARTHVADER to be loaded")
+	}
+	if char.Name != "Darth Vader" || char.MaxZetas != 3 {
+		t.Fatalf("unexpected character data: %+v", char)
+	}
+}
+
+func TestNewCharacterRegistry_YAML(t *testing.T) {
+	dir := t.TempDir()
+	path := writeManifest(t, dir, "characters.yaml", "characters:\n  - id: REY\n    name: Rey\n    max_zetas: 2\n")
+
+	reg, err := NewCharacterRegistry(path)
+	if err != nil {
+		t.Fatalf("NewCharacterRegistry: %v", err)
+	}
+
+	if _, ok := reg.Get("REY"); !ok {
+		t.Fatalf("expected REY to be loaded")
+	}
+}
+
+func TestReload_UnsupportedExtension(t *testing.T) {
+	dir := t.TempDir()
+	path := writeManifest(t, dir, "characters.txt", `{"characters":[]}`)
+
+	if _, err := NewCharacterRegistry(path); err == nil {
+		t.Fatalf("expected an error for an unsupported manifest extension")
+	}
+}
+
+func TestReload_MissingID(t *testing.T) {
+	dir := t.TempDir()
+	path := writeManifest(t, dir, "characters.json", `{"characters":[{"name":"No ID"}]}`)
+
+	if _, err := NewCharacterRegistry(path); err == nil {
+		t.Fatalf("expected an error for a character missing an id")
+	}
+}
+
+func TestReload_DuplicateID(t *testing.T) {
+	dir := t.TempDir()
+	path := writeManifest(t, dir, "characters.json", `{"characters":[{"id":"REY"},{"id":"REY"}]}`)
+
+	if _, err := NewCharacterRegistry(path); err == nil {
+		t.Fatalf("expected an error for a duplicate character id")
+	}
+}
+
+func TestReload_KeepsPreviousRosterOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := writeManifest(t, dir, "characters.json", `{"characters":[{"id":"REY"}]}`)
+
+	reg, err := NewCharacterRegistry(path)
+	if err != nil {
+		t.Fatalf("NewCharacterRegistry: %v", err)
+	}
+
+	writeManifest(t, dir, "characters.json", `not valid json`)
+	if err := reg.Reload(); err == nil {
+		t.Fatalf("expected Reload to fail on invalid JSON")
+	}
+
+	if _, ok := reg.Get("REY"); !ok {
+		t.Fatalf("expected the previously loaded roster to survive a failed reload")
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// charactersHandler serves GET /characters, listing every character in the
+// registry so clients can discover the roster without guessing ids.
+func charactersHandler(reg *CharacterRegistry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(reg.All()); err != nil {
+			http.Error(w, "Failed to encode characters: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// characterByIDHandler serves GET /characters/{id}, returning the single
+// character definition or a 404 if it is not in the manifest.
+func characterByIDHandler(reg *CharacterRegistry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/characters/")
+		if id == "" {
+			http.Error(w, "A character id must be provided", http.StatusBadRequest)
+			return
+		}
+
+		char, ok := reg.Get(id)
+		if !ok {
+			http.Error(w, fmt.Sprintf("Character %q is not in the manifest", id), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(char); err != nil {
+			http.Error(w, "Failed to encode character: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
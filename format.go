@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/chai2010/webp"
+)
+
+// OutputFormat is one of the raster/vector formats the portrait endpoints
+// can emit.
+type OutputFormat string
+
+const (
+	FormatPNG  OutputFormat = "png"
+	FormatJPEG OutputFormat = "jpeg"
+	FormatWebP OutputFormat = "webp"
+	FormatSVG  OutputFormat = "svg"
+)
+
+// ContentType returns the MIME type to send in the response for f.
+func (f OutputFormat) ContentType() string {
+	switch f {
+	case FormatJPEG:
+		return "image/jpeg"
+	case FormatWebP:
+		return "image/webp"
+	case FormatSVG:
+		return "image/svg+xml"
+	default:
+		return "image/png"
+	}
+}
+
+// negotiateFormat picks the response format from an explicit "format" query
+// parameter, falling back to the request's Accept header, and defaulting to
+// PNG to preserve the original behavior.
+func negotiateFormat(r *http.Request) OutputFormat {
+	if f := r.URL.Query().Get("format"); f != "" {
+		switch strings.ToLower(f) {
+		case "png":
+			return FormatPNG
+		case "jpeg", "jpg":
+			return FormatJPEG
+		case "webp":
+			return FormatWebP
+		case "svg":
+			return FormatSVG
+		}
+	}
+
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "image/svg+xml"):
+		return FormatSVG
+	case strings.Contains(accept, "image/webp"):
+		return FormatWebP
+	case strings.Contains(accept, "image/jpeg"):
+		return FormatJPEG
+	default:
+		return FormatPNG
+	}
+}
+
+// encodeRaster writes img to w in the given raster format. quality is used
+// for JPEG and WebP and ignored otherwise. FormatSVG is not a raster format
+// and is rejected.
+func encodeRaster(w io.Writer, img image.Image, format OutputFormat, quality int) error {
+	switch format {
+	case FormatPNG:
+		return png.Encode(w, img)
+	case FormatJPEG:
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+	case FormatWebP:
+		return webp.Encode(w, img, &webp.Options{Quality: float32(quality)})
+	default:
+		return fmt.Errorf("encodeRaster: %q is not a raster format", format)
+	}
+}
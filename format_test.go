@@ -0,0 +1,51 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newFormatRequest(t *testing.T, query, accept string) *http.Request {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodGet, "/create?"+query, nil)
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	return r
+}
+
+func TestNegotiateFormat_QueryParamWins(t *testing.T) {
+	r := newFormatRequest(t, "format=webp", "image/svg+xml")
+	if got := negotiateFormat(r); got != FormatWebP {
+		t.Fatalf("got %q, want %q", got, FormatWebP)
+	}
+}
+
+func TestNegotiateFormat_AcceptHeader(t *testing.T) {
+	cases := map[string]OutputFormat{
+		"image/svg+xml": FormatSVG,
+		"image/webp":    FormatWebP,
+		"image/jpeg":    FormatJPEG,
+	}
+	for accept, want := range cases {
+		r := newFormatRequest(t, "", accept)
+		if got := negotiateFormat(r); got != want {
+			t.Errorf("Accept: %q => got %q, want %q", accept, got, want)
+		}
+	}
+}
+
+func TestNegotiateFormat_DefaultsToPNG(t *testing.T) {
+	r := newFormatRequest(t, "", "")
+	if got := negotiateFormat(r); got != FormatPNG {
+		t.Fatalf("got %q, want %q", got, FormatPNG)
+	}
+}
+
+func TestNegotiateFormat_UnknownQueryParamFallsBackToAccept(t *testing.T) {
+	r := newFormatRequest(t, "format=bmp", "image/webp")
+	if got := negotiateFormat(r); got != FormatWebP {
+		t.Fatalf("got %q, want %q", got, FormatWebP)
+	}
+}
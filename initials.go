@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// initialsPalette is the fixed set of background colors used for initials
+// avatars, in the style of the Vikunja/status-go "identicon" placeholders.
+// A character's background is chosen deterministically by hashing its name.
+var initialsPalette = []color.RGBA{
+	{R: 0xE5, G: 0x39, B: 0x35, A: 0xFF},
+	{R: 0x8E, G: 0x24, B: 0xAA, A: 0xFF},
+	{R: 0x3F, G: 0x51, B: 0xB5, A: 0xFF},
+	{R: 0x00, G: 0x89, B: 0x7B, A: 0xFF},
+	{R: 0x43, G: 0xA0, B: 0x47, A: 0xFF},
+	{R: 0xF9, G: 0xA8, B: 0x25, A: 0xFF},
+	{R: 0xFB, G: 0x8C, B: 0x00, A: 0xFF},
+	{R: 0x6D, G: 0x4C, B: 0x41, A: 0xFF},
+	{R: 0x54, G: 0x6E, B: 0x7A, A: 0xFF},
+}
+
+// paletteColorForName deterministically picks a palette color for name by
+// hashing it with FNV-1a, so the same name always maps to the same color.
+func paletteColorForName(name string) color.RGBA {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return initialsPalette[h.Sum32()%uint32(len(initialsPalette))]
+}
+
+// initialsFor returns up to the first two uppercased initials of name, one
+// per whitespace-separated word.
+func initialsFor(name string) string {
+	words := strings.Fields(name)
+	var b strings.Builder
+	for i, w := range words {
+		if i >= 2 {
+			break
+		}
+		r := []rune(w)
+		if len(r) == 0 {
+			continue
+		}
+		b.WriteRune(r[0])
+	}
+	return strings.ToUpper(b.String())
+}
+
+// RenderInitialsAvatar renders a deterministic placeholder portrait for a
+// character whose asset image is unavailable: a size x size canvas filled
+// with a palette color hashed from name, with name's initials centered in
+// white. bg, if non-nil, overrides the hashed palette color.
+func RenderInitialsAvatar(name string, size int, bg *color.RGBA) (image.Image, error) {
+	canvas := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	background := paletteColorForName(name)
+	if bg != nil {
+		background = *bg
+	}
+	draw.Draw(canvas, canvas.Bounds(), &image.Uniform{background}, image.Point{}, draw.Src)
+
+	fontSize := float64(size) * 80.0 / 200.0
+	face, err := loadFont("assets/fonts/Inter-Regular.ttf", fontSize)
+	if err != nil {
+		return nil, err
+	}
+
+	text := initialsFor(name)
+	textWidth := font.MeasureString(face, text).Round()
+	x := (size - textWidth) / 2
+	y := (size + face.Metrics().Ascent.Ceil()) / 2
+
+	d := &font.Drawer{
+		Dst:  canvas,
+		Src:  image.NewUniform(color.White),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+
+	return canvas, nil
+}
+
+// ParseColor parses a CSS-style color string in #rrggbb, rgb(r,g,b) or
+// rgba(r,g,b,a) form into a color.RGBA.
+func ParseColor(s string) (color.RGBA, error) {
+	s = strings.TrimSpace(s)
+
+	switch {
+	case strings.HasPrefix(s, "#"):
+		hex := strings.TrimPrefix(s, "#")
+		if len(hex) != 6 {
+			return color.RGBA{}, fmt.Errorf("invalid hex color %q: expected #rrggbb", s)
+		}
+		r, err := strconv.ParseUint(hex[0:2], 16, 8)
+		if err != nil {
+			return color.RGBA{}, fmt.Errorf("invalid hex color %q: %w", s, err)
+		}
+		g, err := strconv.ParseUint(hex[2:4], 16, 8)
+		if err != nil {
+			return color.RGBA{}, fmt.Errorf("invalid hex color %q: %w", s, err)
+		}
+		b, err := strconv.ParseUint(hex[4:6], 16, 8)
+		if err != nil {
+			return color.RGBA{}, fmt.Errorf("invalid hex color %q: %w", s, err)
+		}
+		return color.RGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: 0xFF}, nil
+
+	case strings.HasPrefix(s, "rgba("):
+		parts, err := splitColorFunc(s, "rgba(", 4)
+		if err != nil {
+			return color.RGBA{}, err
+		}
+		r, g, b, err := parseRGBTriple(parts[:3])
+		if err != nil {
+			return color.RGBA{}, fmt.Errorf("invalid rgba color %q: %w", s, err)
+		}
+		alpha, err := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
+		if err != nil {
+			return color.RGBA{}, fmt.Errorf("invalid rgba color %q: %w", s, err)
+		}
+		return color.RGBA{R: r, G: g, B: b, A: uint8(alpha * 255)}, nil
+
+	case strings.HasPrefix(s, "rgb("):
+		parts, err := splitColorFunc(s, "rgb(", 3)
+		if err != nil {
+			return color.RGBA{}, err
+		}
+		r, g, b, err := parseRGBTriple(parts)
+		if err != nil {
+			return color.RGBA{}, fmt.Errorf("invalid rgb color %q: %w", s, err)
+		}
+		return color.RGBA{R: r, G: g, B: b, A: 0xFF}, nil
+
+	default:
+		return color.RGBA{}, fmt.Errorf("unrecognized color format %q: expected #rrggbb, rgb(...) or rgba(...)", s)
+	}
+}
+
+// splitColorFunc splits the comma-separated arguments out of a "func(...)"
+// color string, verifying it has exactly n parts.
+func splitColorFunc(s, prefix string, n int) ([]string, error) {
+	if !strings.HasSuffix(s, ")") {
+		return nil, fmt.Errorf("invalid color %q: missing closing ')'", s)
+	}
+	inner := strings.TrimSuffix(strings.TrimPrefix(s, prefix), ")")
+	parts := strings.Split(inner, ",")
+	if len(parts) != n {
+		return nil, fmt.Errorf("invalid color %q: expected %d components, got %d", s, n, len(parts))
+	}
+	return parts, nil
+}
+
+// parseRGBTriple parses three comma-separated 0-255 integer components.
+func parseRGBTriple(parts []string) (r, g, b uint8, err error) {
+	values := make([]uint8, 3)
+	for i, p := range parts {
+		v, err := strconv.ParseUint(strings.TrimSpace(p), 10, 8)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		values[i] = uint8(v)
+	}
+	return values[0], values[1], values[2], nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"image/png"
+	"net/http"
+	"strconv"
+)
+
+// initialsHandler serves GET /initials?name=...&size=...&bg=..., rendering
+// an initials avatar directly without needing a registered character.
+func initialsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	name := query.Get("name")
+	if name == "" {
+		http.Error(w, "The name parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	size := 200
+	if sizeStr := query.Get("size"); sizeStr != "" {
+		parsed, err := strconv.Atoi(sizeStr)
+		if err != nil || parsed <= 0 || parsed > maxCanvasSize {
+			http.Error(w, fmt.Sprintf("The size parameter must be between 1 and %d", maxCanvasSize), http.StatusBadRequest)
+			return
+		}
+		size = parsed
+	}
+
+	var bg *color.RGBA
+	if bgStr := query.Get("bg"); bgStr != "" {
+		parsed, err := ParseColor(bgStr)
+		if err != nil {
+			http.Error(w, "Invalid bg parameter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		bg = &parsed
+	}
+
+	img, err := RenderInitialsAvatar(name, size, bg)
+	if err != nil {
+		http.Error(w, "Failed to render initials avatar: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	if err := png.Encode(w, img); err != nil {
+		http.Error(w, "Failed to encode image: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestParseColor_Hex(t *testing.T) {
+	got, err := ParseColor("#E53935")
+	if err != nil {
+		t.Fatalf("ParseColor: %v", err)
+	}
+	want := color.RGBA{R: 0xE5, G: 0x39, B: 0x35, A: 0xFF}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestParseColor_RGB(t *testing.T) {
+	got, err := ParseColor("rgb(10, 20, 30)")
+	if err != nil {
+		t.Fatalf("ParseColor: %v", err)
+	}
+	want := color.RGBA{R: 10, G: 20, B: 30, A: 0xFF}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestParseColor_RGBA(t *testing.T) {
+	got, err := ParseColor("rgba(10, 20, 30, 0.5)")
+	if err != nil {
+		t.Fatalf("ParseColor: %v", err)
+	}
+	want := color.RGBA{R: 10, G: 20, B: 30, A: 127}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestParseColor_Errors(t *testing.T) {
+	cases := []string{
+		"",
+		"#fff",
+		"#gggggg",
+		"rgb(1,2)",
+		"rgb(1,2,3",
+		"not-a-color",
+	}
+	for _, s := range cases {
+		if _, err := ParseColor(s); err == nil {
+			t.Errorf("ParseColor(%q): expected an error, got none", s)
+		}
+	}
+}
+
+func TestInitialsFor(t *testing.T) {
+	cases := map[string]string{
+		"Darth Vader":          "DV",
+		"Rey":                  "R",
+		"":                     "",
+		"  padme amidala":      "PA",
+		"Grand Admiral Thrawn": "GA",
+	}
+	for name, want := range cases {
+		if got := initialsFor(name); got != want {
+			t.Errorf("initialsFor(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestPaletteColorForName_Deterministic(t *testing.T) {
+	a := paletteColorForName("Darth Vader")
+	b := paletteColorForName("Darth Vader")
+	if a != b {
+		t.Fatalf("expected paletteColorForName to be deterministic, got %+v vs %+v", a, b)
+	}
+}
+
+func TestPaletteColorForName_InPalette(t *testing.T) {
+	got := paletteColorForName("Some Character")
+	for _, c := range initialsPalette {
+		if c == got {
+			return
+		}
+	}
+	t.Fatalf("paletteColorForName returned a color not in initialsPalette: %+v", got)
+}
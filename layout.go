@@ -0,0 +1,51 @@
+package main
+
+import "image"
+
+// baseCanvasSize is the canvas size the original hand-tuned badge positions
+// were designed against. Layout scales every other size proportionally to
+// this baseline.
+const baseCanvasSize = 200
+
+// Layout holds the canvas size and every badge/text position and font size
+// needed to compose a portrait, scaled proportionally from the original
+// 200x200 design so portraits can be rendered at any requested size.
+type Layout struct {
+	Size int
+
+	ZetaBadgePos     image.Point
+	ZetaBadgeSize    image.Point
+	OmicronBadgePos  image.Point
+	OmicronBadgeSize image.Point
+	LevelBadgePos    image.Point
+	LevelBadgeSize   image.Point
+
+	SmallFontSize float64
+	LargeFontSize float64
+}
+
+// NewLayout builds a Layout for the given canvas size, scaling every
+// constant from the original 200x200 design by size/baseCanvasSize.
+func NewLayout(size int) Layout {
+	scale := float64(size) / baseCanvasSize
+	scalePt := func(p image.Point) image.Point {
+		return image.Point{
+			X: int(float64(p.X) * scale),
+			Y: int(float64(p.Y) * scale),
+		}
+	}
+
+	return Layout{
+		Size: size,
+
+		ZetaBadgePos:     scalePt(image.Point{X: 18, Y: 100}),
+		ZetaBadgeSize:    scalePt(image.Point{X: 60, Y: 60}),
+		OmicronBadgePos:  scalePt(image.Point{X: 121, Y: 100}),
+		OmicronBadgeSize: scalePt(image.Point{X: 60, Y: 60}),
+		LevelBadgePos:    scalePt(image.Point{X: 75, Y: 128}),
+		LevelBadgeSize:   scalePt(image.Point{X: 50, Y: 44}),
+
+		SmallFontSize: 18 * scale,
+		LargeFontSize: 24 * scale,
+	}
+}
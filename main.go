@@ -1,11 +1,11 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"image"
 	"image/color"
 	"image/draw"
-	"image/png"
 	"io/ioutil"
 	"log"
 	"net/http"
@@ -16,6 +16,8 @@ import (
 
 	"golang.org/x/image/font/opentype"
 	"golang.org/x/image/math/fixed"
+
+	"github.com/AndehUK/swgoh-portraits/swgohclient"
 )
 
 type CharacterPortrait struct {
@@ -24,26 +26,34 @@ type CharacterPortrait struct {
 	RelicLevel int
 	Zetas      int
 	Omicrons   int
+	// Level is the character's level badge text. Endpoints that don't track
+	// a real level (/create, /roster) leave this at 0, which buildPortrait
+	// treats as defaultPortraitLevel.
+	Level int
 }
 
-type Character struct {
-	Name        string
-	Affiliation string
-	imgSrc      string
-	maxZetas    int
-	maxOmicrons int
-}
+// defaultPortraitLevel is drawn on the level badge when a CharacterPortrait
+// doesn't carry a real Level, e.g. from /create or /roster.
+const defaultPortraitLevel = 85
+
+// characterManifestPath is the default location of the character registry
+// manifest; override with the CHARACTER_MANIFEST environment variable.
+const characterManifestPath = "characters.json"
+
+const (
+	// portraitCacheCapacity bounds the in-memory LRU tier of PortraitCache.
+	portraitCacheCapacity = 1024
+	// portraitCacheDir is where the on-disk cache tier persists encoded portraits.
+	portraitCacheDir = "cache/portraits"
+	// portraitCacheMaxAge is the Cache-Control max-age, in seconds, advertised
+	// for rendered portraits.
+	portraitCacheMaxAge = 86400
+)
 
-// List of supported characters
-var supportedCharacters = map[string]Character{
-	"darth_vader": {
-		Name:        "Darth Vader",
-		Affiliation: "dark_side",
-		imgSrc:      "darth_vader.png",
-		maxZetas:    3,
-		maxOmicrons: 1,
-	},
-}
+// maxCanvasSize bounds the effective size*dpr pixel dimensions a single
+// request can ask for, so a large size/dpr combination can't force an
+// unbounded image.NewRGBA allocation.
+const maxCanvasSize = 2048
 
 // Load the Inter font
 func loadFont(path string, size float64) (font.Face, error) {
@@ -79,7 +89,34 @@ func drawText(img *image.RGBA, face font.Face, x, y int, text string, col color.
 }
 
 func main() {
-	http.HandleFunc("/create", createPortraitHandler)
+	manifestPath := os.Getenv("CHARACTER_MANIFEST")
+	if manifestPath == "" {
+		manifestPath = characterManifestPath
+	}
+
+	registry, err := NewCharacterRegistry(manifestPath)
+	if err != nil {
+		log.Fatalf("Failed to load character registry: %v", err)
+	}
+	watchForReload(registry)
+
+	if err := preloadAssets(registry); err != nil {
+		log.Fatalf("Failed to preload assets: %v", err)
+	}
+
+	cache, err := NewPortraitCache(portraitCacheCapacity, portraitCacheDir)
+	if err != nil {
+		log.Fatalf("Failed to open portrait cache: %v", err)
+	}
+
+	http.HandleFunc("/create", createPortraitHandler(registry, cache))
+	http.HandleFunc("/characters", charactersHandler(registry))
+	http.HandleFunc("/characters/", characterByIDHandler(registry))
+	http.HandleFunc("/initials", initialsHandler)
+	http.HandleFunc("/roster", rosterHandler(registry))
+
+	swgohClient := swgohclient.NewCachingClient(swgohclient.NewSWGOHGGClient(), 0)
+	http.HandleFunc("/player/", playerPortraitHandler(registry, swgohClient, cache))
 
 	fmt.Println("Server is running on http://localhost:3000")
 	if err := http.ListenAndServe(":3000", nil); err != nil {
@@ -87,68 +124,129 @@ func main() {
 	}
 }
 
-func createPortraitHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != "GET" {
-		http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
-		return
+func createPortraitHandler(reg *CharacterRegistry, cache *PortraitCache) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := r.URL.Query()
+		charID := query.Get("char")
+
+		char, ok := reg.Get(charID)
+		if !ok {
+			http.Error(w, fmt.Sprintf("Character '%s' is not supported by this API", charID), http.StatusBadRequest)
+			return
+		}
+
+		gearLevel, _ := getIntFromQuery(query, "gear_level")
+		relicLevel, _ := getIntFromQuery(query, "relic_level")
+		zetas, _ := getIntFromQuery(query, "zetas")       // Error ignored to allow default value of 0
+		omicrons, _ := getIntFromQuery(query, "omicrons") // Error ignored to allow default value of 0
+
+		portrait := CharacterPortrait{
+			Character:  charID,
+			GearLevel:  gearLevel,
+			RelicLevel: relicLevel,
+			Zetas:      zetas,
+			Omicrons:   omicrons,
+		}
+
+		if err := validatePortrait(char, portrait); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		respondWithPortrait(w, r, cache, portrait, char)
 	}
+}
 
+// respondWithPortrait parses the common size/dpr/quality/format query
+// parameters, renders portrait through the cache, and writes the response
+// with ETag/Cache-Control headers. It's shared by every endpoint that serves
+// a rendered portrait (createPortraitHandler, playerPortraitHandler) so they
+// stay consistent in caching, content negotiation, and the size/dpr bounds
+// that guard against unbounded allocation.
+func respondWithPortrait(w http.ResponseWriter, r *http.Request, cache *PortraitCache, portrait CharacterPortrait, char Character) {
 	query := r.URL.Query()
-	charID := query.Get("char")
 
-	char, ok := supportedCharacters[charID]
-	if !ok {
-		http.Error(w, fmt.Sprintf("Character '%s' is not supported by this API", charID), http.StatusBadRequest)
+	size, err := getIntFromQuery(query, "size")
+	if err != nil || size < 0 || size > maxCanvasSize {
+		http.Error(w, fmt.Sprintf("The size parameter must be between 1 and %d", maxCanvasSize), http.StatusBadRequest)
 		return
 	}
-
-	gearLevel, err := getIntFromQuery(query, "gear_level")
-	if err != nil || gearLevel < 1 || gearLevel > 13 {
-		http.Error(w, "The gear_level must be between 1 and 13", http.StatusBadRequest)
-		return
+	if size == 0 {
+		size = baseCanvasSize
 	}
 
-	relicLevel, err := getIntFromQuery(query, "relic_level")
-	if gearLevel != 13 && relicLevel != 0 && err == nil {
-		http.Error(w, "The relic_level should not be provided if gear_level is not 13", http.StatusBadRequest)
+	dpr, err := getIntFromQuery(query, "dpr")
+	if err != nil || dpr < 0 || dpr > 3 {
+		http.Error(w, "The dpr parameter must be between 1 and 3", http.StatusBadRequest)
 		return
 	}
-	if gearLevel == 13 && (relicLevel < 1 || relicLevel > 9) {
-		http.Error(w, "The relic_level must be between 1 and 9", http.StatusBadRequest)
-		return
+	if dpr == 0 {
+		dpr = 1
 	}
 
-	zetas, _ := getIntFromQuery(query, "zetas") // Error ignored to allow default value of 0
-	if zetas < 0 || zetas > char.maxZetas {
-		http.Error(w, fmt.Sprintf("The zeta level must be between 0 and %d for %s", char.maxZetas, char.Name), http.StatusBadRequest)
+	if size*dpr > maxCanvasSize {
+		http.Error(w, fmt.Sprintf("The size and dpr combination must not exceed %d effective pixels", maxCanvasSize), http.StatusBadRequest)
 		return
 	}
 
-	omicrons, _ := getIntFromQuery(query, "omicrons") // Error ignored to allow default value of 0
-	if omicrons < 0 || omicrons > char.maxOmicrons {
-		http.Error(w, fmt.Sprintf("The omicron level must be between 0 and %d for %s", char.maxOmicrons, char.Name), http.StatusBadRequest)
+	quality, err := getIntFromQuery(query, "quality")
+	if err != nil || quality < 0 || quality > 100 {
+		http.Error(w, "The quality parameter must be between 0 and 100", http.StatusBadRequest)
 		return
 	}
-
-	portrait := CharacterPortrait{
-		Character:  charID,
-		GearLevel:  gearLevel,
-		RelicLevel: relicLevel,
-		Zetas:      zetas,
-		Omicrons:   omicrons,
+	if quality == 0 {
+		quality = 90
 	}
 
-	img, err := buildPortrait(portrait, char)
-	if err != nil {
-		http.Error(w, "Failed to create portrait: "+err.Error(), http.StatusInternalServerError)
-		return
+	layout := NewLayout(size * dpr)
+	format := negotiateFormat(r)
+
+	key := CacheKey(portrait.Character, portrait.GearLevel, portrait.RelicLevel, portrait.Zetas, portrait.Omicrons, portrait.Level, layout.Size, format, quality)
+	etag := `"` + key + `"`
+
+	data, ok := cache.Get(key)
+	if !ok {
+		if format == FormatSVG {
+			svg, err := buildPortraitSVG(portrait, char, layout)
+			if err != nil {
+				http.Error(w, "Failed to create portrait: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			data = []byte(svg)
+		} else {
+			img, err := buildPortrait(portrait, char, layout)
+			if err != nil {
+				http.Error(w, "Failed to create portrait: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			var buf bytes.Buffer
+			if err := encodeRaster(&buf, img, format, quality); err != nil {
+				http.Error(w, "Failed to encode image: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			data = buf.Bytes()
+		}
+
+		if err := cache.Put(key, data); err != nil {
+			log.Printf("portrait cache: failed to store %q: %v", key, err)
+		}
 	}
 
-	w.Header().Set("Content-Type", "image/png")
-	if err := png.Encode(w, img); err != nil {
-		http.Error(w, "Failed to encode image: "+err.Error(), http.StatusInternalServerError)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", portraitCacheMaxAge))
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
 		return
 	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Write(data)
 }
 
 // getIntFromQuery is a helper function to get integer values from query parameters.
@@ -166,23 +264,30 @@ func getIntFromQuery(query map[string][]string, key string) (int, error) {
 	return value, nil
 }
 
-func buildPortrait(portrait CharacterPortrait, charData Character) (image.Image, error) {
-	interFontFaceSmall, err := loadFont("assets/fonts/Inter-Regular.ttf", 18)
+// buildPortrait composes a character portrait at the native 200x200 asset
+// resolution, then resizes the finished composite to layout.Size (if it
+// differs) using Catmull-Rom interpolation. Composing at native resolution
+// first keeps placeImageOnCanvas's centering (for non-square character art)
+// intact instead of stretching assets to fit an arbitrary target size.
+func buildPortrait(portrait CharacterPortrait, charData Character, layout Layout) (image.Image, error) {
+	baseLayout := NewLayout(baseCanvasSize)
+
+	interFontFaceSmall, err := cachedFont(baseLayout.SmallFontSize)
 	if err != nil {
 		return nil, err
 	}
 
-	interFontFaceLarge, err := loadFont("assets/fonts/Inter-Regular.ttf", 24)
+	interFontFaceLarge, err := cachedFont(baseLayout.LargeFontSize)
 	if err != nil {
 		return nil, err
 	}
 
-	zetaBadgePosition := image.Point{18, 100}
-	zetaBadgeSize := image.Point{60, 60}
-	omicronBadgePosition := image.Point{121, 100}
-	omicronBadgeSize := image.Point{60, 60}
-	levelBadgePosition := image.Point{75, 128}
-	levelBadgeSize := image.Point{50, 44}
+	zetaBadgePosition := baseLayout.ZetaBadgePos
+	zetaBadgeSize := baseLayout.ZetaBadgeSize
+	omicronBadgePosition := baseLayout.OmicronBadgePos
+	omicronBadgeSize := baseLayout.OmicronBadgeSize
+	levelBadgePosition := baseLayout.LevelBadgePos
+	levelBadgeSize := baseLayout.LevelBadgeSize
 
 	// Calculate text drawing positions
 	zetaText := strconv.Itoa(portrait.Zetas)
@@ -199,26 +304,37 @@ func buildPortrait(portrait CharacterPortrait, charData Character) (image.Image,
 		Y: (omicronBadgePosition.Y + (omicronBadgeSize.Y+interFontFaceSmall.Metrics().Ascent.Ceil())/2) - 4,
 	}
 
-	levelText := "85" // The level is hardcoded in this example; you may want to make this dynamic
+	level := portrait.Level
+	if level == 0 {
+		level = defaultPortraitLevel
+	}
+	levelText := strconv.Itoa(level)
 	levelTextWidth := font.MeasureString(interFontFaceLarge, levelText).Round()
 	levelTextPosition := image.Point{
 		X: levelBadgePosition.X + (levelBadgeSize.X-levelTextWidth)/2,
 		Y: (levelBadgePosition.Y + (levelBadgeSize.Y+interFontFaceLarge.Metrics().Ascent.Ceil())/2) - 5,
 	}
 
-	// Load and center the character image on a 200x200 canvas
-	characterImg, err := loadImage("assets/characters/" + charData.imgSrc)
+	// Load and center the character image on the canvas, falling back to a
+	// generated initials avatar if the asset is missing.
+	var finalImage *image.RGBA
+	characterImg, err := cachedImage("assets/characters/" + charData.ImgSrc)
 	if err != nil {
-		return nil, err
+		initialsImg, initialsErr := RenderInitialsAvatar(charData.Name, baseCanvasSize, nil)
+		if initialsErr != nil {
+			return nil, initialsErr
+		}
+		finalImage, err = placeImageOnCanvas(initialsImg, baseCanvasSize)
+	} else {
+		finalImage, err = placeImageOnCanvas(characterImg, baseCanvasSize)
 	}
-	finalImage, err := placeImageOnCanvas(characterImg)
 	if err != nil {
 		return nil, err
 	}
 
 	// Add gear or relic border based on GearLevel
 	if portrait.GearLevel < 13 {
-		borderImg, err := loadImage("assets/gear/" + strconv.Itoa(portrait.GearLevel) + ".png")
+		borderImg, err := cachedImage("assets/gear/" + strconv.Itoa(portrait.GearLevel) + ".png")
 		if err != nil {
 			return nil, err
 		}
@@ -226,7 +342,7 @@ func buildPortrait(portrait CharacterPortrait, charData Character) (image.Image,
 	} else {
 		// Load and draw relic border for gear level 13
 		// Assuming relic border path is similar to gear
-		relicBorderImg, err := loadImage("assets/relics/" + charData.Affiliation + ".png") // Placeholder path
+		relicBorderImg, err := cachedImage("assets/relics/" + charData.Affiliation + ".png") // Placeholder path
 		if err != nil {
 			return nil, err
 		}
@@ -234,7 +350,7 @@ func buildPortrait(portrait CharacterPortrait, charData Character) (image.Image,
 	}
 
 	// Add character level badge and level number text
-	levelBadgeImg, err := loadImage("assets/badges/level.png")
+	levelBadgeImg, err := cachedImage("assets/badges/level.png")
 	if err != nil {
 		return nil, err
 	}
@@ -243,7 +359,7 @@ func buildPortrait(portrait CharacterPortrait, charData Character) (image.Image,
 
 	// Conditionally add zeta badge
 	if portrait.Zetas > 0 {
-		zetaBadgeImg, err := loadImage("assets/badges/zeta.png")
+		zetaBadgeImg, err := cachedImage("assets/badges/zeta.png")
 		if err != nil {
 			return nil, err
 		}
@@ -253,7 +369,7 @@ func buildPortrait(portrait CharacterPortrait, charData Character) (image.Image,
 
 	// Conditionally add omicron badge
 	if portrait.Omicrons > 0 {
-		omicronBadgeImg, err := loadImage("assets/badges/omicron.png")
+		omicronBadgeImg, err := cachedImage("assets/badges/omicron.png")
 		if err != nil {
 			return nil, err
 		}
@@ -261,7 +377,10 @@ func buildPortrait(portrait CharacterPortrait, charData Character) (image.Image,
 		drawText(finalImage, interFontFaceSmall, omicronTextPosition.X, omicronTextPosition.Y, omicronText, color.White)
 	}
 
-	// Return the composed final image
+	if layout.Size != baseCanvasSize {
+		return resizeImage(finalImage, layout.Size, layout.Size), nil
+	}
+
 	return finalImage, nil
 }
 
@@ -283,10 +402,10 @@ func loadImage(filePath string) (image.Image, error) {
 	return img, nil
 }
 
-// placeImageOnCanvas centers an image onto a 200x200 canvas
-func placeImageOnCanvas(src image.Image) (*image.RGBA, error) {
-	// Create a new blank 200x200 canvas
-	canvasSize := image.Point{200, 200}
+// placeImageOnCanvas centers an image onto a size x size canvas
+func placeImageOnCanvas(src image.Image, size int) (*image.RGBA, error) {
+	// Create a new blank size x size canvas
+	canvasSize := image.Point{size, size}
 	canvasRect := image.Rectangle{image.Point{0, 0}, canvasSize}
 	canvas := image.NewRGBA(canvasRect)
 
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/AndehUK/swgoh-portraits/swgohclient"
+)
+
+// playerPortraitHandler serves GET /player/{allyCode}/portrait/{charID},
+// fetching the player's live roster state from client and rendering it
+// through respondWithPortrait, so Discord bots and websites can hand over
+// just an ally code instead of manually tracking gear/relic/zeta/omicron
+// state. It supports the same size/dpr/quality/format query parameters and
+// cache/ETag behavior as createPortraitHandler.
+func playerPortraitHandler(reg *CharacterRegistry, client swgohclient.Client, cache *PortraitCache) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		allyCode, charID, err := parsePlayerPortraitPath(r.URL.Path)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		char, ok := reg.Get(charID)
+		if !ok {
+			http.Error(w, fmt.Sprintf("Character '%s' is not supported by this API", charID), http.StatusBadRequest)
+			return
+		}
+
+		roster, err := client.FetchRoster(r.Context(), allyCode)
+		if err != nil {
+			http.Error(w, "Failed to fetch player roster: "+err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		state, ok := roster.Characters[charID]
+		if !ok {
+			http.Error(w, fmt.Sprintf("Player %s does not own %s", allyCode, charID), http.StatusNotFound)
+			return
+		}
+
+		portrait := CharacterPortrait{
+			Character:  charID,
+			GearLevel:  state.GearLevel,
+			RelicLevel: state.RelicLevel,
+			Zetas:      state.Zetas,
+			Omicrons:   state.Omicrons,
+			Level:      state.Level,
+		}
+
+		if err := validatePortrait(char, portrait); err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+
+		respondWithPortrait(w, r, cache, portrait, char)
+	}
+}
+
+// parsePlayerPortraitPath extracts the ally code and character id from a
+// /player/{allyCode}/portrait/{charID} request path.
+func parsePlayerPortraitPath(path string) (allyCode, charID string, err error) {
+	parts := strings.Split(strings.TrimPrefix(path, "/player/"), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] != "portrait" || parts[2] == "" {
+		return "", "", fmt.Errorf("expected path /player/{allyCode}/portrait/{charID}, got %q", path)
+	}
+	return parts[0], parts[2], nil
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchForReload reloads reg whenever the process receives SIGHUP, e.g.
+// `kill -HUP <pid>` after editing the character manifest. It runs until the
+// process exits, logging reload failures without crashing the server.
+func watchForReload(reg *CharacterRegistry) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+
+	go func() {
+		for range sighup {
+			if err := reg.Reload(); err != nil {
+				log.Printf("character registry: reload failed: %v", err)
+				continue
+			}
+			log.Println("character registry: reloaded manifest")
+		}
+	}()
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"image"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// resizeImage scales src to exactly w x h using Catmull-Rom interpolation,
+// which gives noticeably better quality than nearest-neighbor for the
+// down/up-scaling done when honoring size and dpr parameters.
+func resizeImage(src image.Image, w, h int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), xdraw.Over, nil)
+	return dst
+}
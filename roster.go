@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/image/font"
+)
+
+const (
+	// rosterDefaultColumns is used when a roster request doesn't specify one.
+	rosterDefaultColumns = 5
+	// rosterMaxColumns bounds how wide a requested grid can be.
+	rosterMaxColumns = 10
+	// rosterMaxPortraits bounds how many portraits a single request can render,
+	// so the composed sheet's dimensions can't grow unbounded.
+	rosterMaxPortraits = 50
+	// rosterMaxBodyBytes caps the request body size rosterHandler will decode.
+	rosterMaxBodyBytes = 1 << 20 // 1 MiB
+	// rosterMaxWorkers bounds how many portraits are rendered concurrently.
+	rosterMaxWorkers = 8
+
+	rosterTitleHeight    = 48
+	rosterTitleFontSize  = 28
+	rosterFooterHeight   = 28
+	rosterFooterFontSize = 14
+	rosterFooterPadding  = 10
+)
+
+// RosterRequest is the POST /roster request body: the squad to render, how
+// many columns wide the sheet should be (defaulting to rosterDefaultColumns),
+// and an optional title drawn above the grid.
+type RosterRequest struct {
+	Portraits []CharacterPortrait `json:"portraits"`
+	Columns   int                 `json:"columns"`
+	Title     string              `json:"title"`
+}
+
+// rosterHandler serves POST /roster, composing every portrait in the
+// request into a single grid sheet so guild leads can share a whole squad
+// in one image instead of stitching together individual /create calls.
+func rosterHandler(reg *CharacterRegistry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, rosterMaxBodyBytes)
+
+		var req RosterRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(req.Portraits) == 0 {
+			http.Error(w, "At least one portrait must be provided", http.StatusBadRequest)
+			return
+		}
+		if len(req.Portraits) > rosterMaxPortraits {
+			http.Error(w, fmt.Sprintf("At most %d portraits may be requested at once", rosterMaxPortraits), http.StatusBadRequest)
+			return
+		}
+
+		columns := req.Columns
+		if columns <= 0 {
+			columns = rosterDefaultColumns
+		}
+		if columns > rosterMaxColumns {
+			http.Error(w, fmt.Sprintf("The columns parameter must not exceed %d", rosterMaxColumns), http.StatusBadRequest)
+			return
+		}
+
+		for _, p := range req.Portraits {
+			char, ok := reg.Get(p.Character)
+			if !ok {
+				http.Error(w, fmt.Sprintf("Character '%s' is not supported by this API", p.Character), http.StatusBadRequest)
+				return
+			}
+			if err := validatePortrait(char, p); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		sheet, err := buildRosterSheet(reg, req.Portraits, columns, req.Title)
+		if err != nil {
+			http.Error(w, "Failed to build roster sheet: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/png")
+		if err := png.Encode(w, sheet); err != nil {
+			http.Error(w, "Failed to encode image: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// buildRosterSheet renders every portrait concurrently through a worker
+// pool (reusing buildPortrait and the preloaded asset/font caches) and
+// composes them into a grid, with an optional title and a generation
+// timestamp footer.
+func buildRosterSheet(reg *CharacterRegistry, portraits []CharacterPortrait, columns int, title string) (image.Image, error) {
+	cellSize := baseCanvasSize
+	layout := NewLayout(cellSize)
+
+	rendered, err := renderPortraitsConcurrently(reg, portraits, layout)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := (len(portraits) + columns - 1) / columns
+
+	titleHeight := 0
+	if title != "" {
+		titleHeight = rosterTitleHeight
+	}
+
+	sheetWidth := columns * cellSize
+	sheetHeight := titleHeight + rows*cellSize + rosterFooterHeight
+
+	sheet := image.NewRGBA(image.Rect(0, 0, sheetWidth, sheetHeight))
+	draw.Draw(sheet, sheet.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+
+	if title != "" {
+		titleFace, err := cachedFont(rosterTitleFontSize)
+		if err != nil {
+			return nil, err
+		}
+		titleWidth := font.MeasureString(titleFace, title).Round()
+		x := (sheetWidth - titleWidth) / 2
+		y := titleHeight/2 + titleFace.Metrics().Ascent.Ceil()/2
+		drawText(sheet, titleFace, x, y, title, color.White)
+	}
+
+	for i, img := range rendered {
+		row := i / columns
+		col := i % columns
+		pos := image.Point{X: col * cellSize, Y: titleHeight + row*cellSize}
+		draw.Draw(sheet, img.Bounds().Add(pos), img, image.Point{}, draw.Over)
+	}
+
+	footerFace, err := cachedFont(rosterFooterFontSize)
+	if err != nil {
+		return nil, err
+	}
+	footerText := "Generated " + time.Now().Format(time.RFC1123)
+	footerY := titleHeight + rows*cellSize + rosterFooterHeight/2 + footerFace.Metrics().Ascent.Ceil()/2
+	drawText(sheet, footerFace, rosterFooterPadding, footerY, footerText, color.White)
+
+	return sheet, nil
+}
+
+// renderPortraitsConcurrently builds every portrait through a bounded pool
+// of workers, returning the results in the same order as portraits.
+func renderPortraitsConcurrently(reg *CharacterRegistry, portraits []CharacterPortrait, layout Layout) ([]image.Image, error) {
+	type result struct {
+		img image.Image
+		err error
+	}
+
+	jobs := make(chan int)
+	results := make([]result, len(portraits))
+
+	workers := rosterMaxWorkers
+	if workers > len(portraits) {
+		workers = len(portraits)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				p := portraits[index]
+				char, ok := reg.Get(p.Character)
+				if !ok {
+					results[index] = result{err: fmt.Errorf("character %q is not supported by this API", p.Character)}
+					continue
+				}
+				img, err := buildPortrait(p, char, layout)
+				results[index] = result{img: img, err: err}
+			}
+		}()
+	}
+
+	for i := range portraits {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	rendered := make([]image.Image, len(portraits))
+	for i, res := range results {
+		if res.err != nil {
+			return nil, res.err
+		}
+		rendered[i] = res.img
+	}
+
+	return rendered, nil
+}
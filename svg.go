@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"image"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// buildPortraitSVG composes a portrait as an SVG document. Asset PNGs are
+// embedded as base64 data URIs rather than referencing their on-disk paths,
+// since this server has no route serving /assets/... over HTTP — a plain
+// file-path href would 404 for every real client.
+func buildPortraitSVG(portrait CharacterPortrait, charData Character, layout Layout) (string, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`,
+		layout.Size, layout.Size, layout.Size, layout.Size)
+
+	charX, charY, charW, charH := centeredAssetRect("assets/characters/"+charData.ImgSrc, layout.Size)
+	if err := writeImage(&b, "assets/characters/"+charData.ImgSrc, charX, charY, charW, charH); err != nil {
+		return "", err
+	}
+
+	if portrait.GearLevel < 13 {
+		if err := writeImage(&b, "assets/gear/"+strconv.Itoa(portrait.GearLevel)+".png", 0, 0, layout.Size, layout.Size); err != nil {
+			return "", err
+		}
+	} else {
+		if err := writeImage(&b, "assets/relics/"+charData.Affiliation+".png", 0, 0, layout.Size, layout.Size); err != nil {
+			return "", err
+		}
+	}
+
+	if err := writeImage(&b, "assets/badges/level.png", layout.LevelBadgePos.X, layout.LevelBadgePos.Y, layout.LevelBadgeSize.X, layout.LevelBadgeSize.Y); err != nil {
+		return "", err
+	}
+	level := portrait.Level
+	if level == 0 {
+		level = defaultPortraitLevel
+	}
+	writeBadgeText(&b, strconv.Itoa(level), layout.LevelBadgePos, layout.LevelBadgeSize, layout.LargeFontSize)
+
+	if portrait.Zetas > 0 {
+		if err := writeImage(&b, "assets/badges/zeta.png", layout.ZetaBadgePos.X, layout.ZetaBadgePos.Y, layout.ZetaBadgeSize.X, layout.ZetaBadgeSize.Y); err != nil {
+			return "", err
+		}
+		writeBadgeText(&b, strconv.Itoa(portrait.Zetas), layout.ZetaBadgePos, layout.ZetaBadgeSize, layout.SmallFontSize)
+	}
+
+	if portrait.Omicrons > 0 {
+		if err := writeImage(&b, "assets/badges/omicron.png", layout.OmicronBadgePos.X, layout.OmicronBadgePos.Y, layout.OmicronBadgeSize.X, layout.OmicronBadgeSize.Y); err != nil {
+			return "", err
+		}
+		writeBadgeText(&b, strconv.Itoa(portrait.Omicrons), layout.OmicronBadgePos, layout.OmicronBadgeSize, layout.SmallFontSize)
+	}
+
+	b.WriteString("</svg>")
+
+	return b.String(), nil
+}
+
+// writeImage appends an <image> element embedding the PNG at path as a
+// base64 data URI, positioned at x,y with size w x h.
+func writeImage(b *strings.Builder, path string, x, y, w, h int) error {
+	dataURI, err := assetDataURI(path)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(b, `<image href="%s" x="%d" y="%d" width="%d" height="%d"/>`, dataURI, x, y, w, h)
+	return nil
+}
+
+// centeredAssetRect returns the x, y, width and height at which the asset at
+// path should be drawn within a canvasSize x canvasSize area so it's centered
+// at its native aspect ratio, scaled up from the base 200x200 design like
+// every other layout dimension. This mirrors placeImageOnCanvas's centering
+// for the raster path, so an SVG response doesn't stretch non-square
+// character art the way a naive full-bleed <image> would.
+func centeredAssetRect(path string, canvasSize int) (x, y, w, h int) {
+	img, err := cachedImage(path)
+	if err != nil {
+		return 0, 0, canvasSize, canvasSize
+	}
+
+	scale := float64(canvasSize) / baseCanvasSize
+	srcSize := img.Bounds().Size()
+	w = int(float64(srcSize.X) * scale)
+	h = int(float64(srcSize.Y) * scale)
+	x = (canvasSize - w) / 2
+	y = (canvasSize - h) / 2
+	return x, y, w, h
+}
+
+// assetDataURI reads the PNG asset at path and returns it as a
+// "data:image/png;base64,..." URI suitable for an inline <image> href.
+func assetDataURI(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// writeBadgeText appends a <text> element centered within a badge's bounds.
+func writeBadgeText(b *strings.Builder, text string, pos, size image.Point, fontSize float64) {
+	cx := pos.X + size.X/2
+	cy := pos.Y + size.Y/2
+	fmt.Fprintf(b, `<text x="%d" y="%d" font-family="Inter" font-size="%.1f" fill="white" text-anchor="middle" dominant-baseline="central">%s</text>`,
+		cx, cy, fontSize, text)
+}
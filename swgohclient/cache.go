@@ -0,0 +1,61 @@
+package swgohclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultTTL is how long a fetched roster is considered fresh before
+// CachingClient will hit the upstream client again for the same ally code.
+const DefaultTTL = 10 * time.Minute
+
+type cacheEntry struct {
+	roster    *PlayerRoster
+	expiresAt time.Time
+}
+
+// CachingClient wraps a Client with an in-memory TTL cache keyed by ally
+// code, so repeated portrait requests for the same player don't hammer the
+// upstream roster API.
+type CachingClient struct {
+	upstream Client
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// NewCachingClient wraps upstream with a TTL cache. A ttl of 0 uses DefaultTTL.
+func NewCachingClient(upstream Client, ttl time.Duration) *CachingClient {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &CachingClient{
+		upstream: upstream,
+		ttl:      ttl,
+		entries:  make(map[string]cacheEntry),
+	}
+}
+
+// FetchRoster returns the cached roster for allyCode if it hasn't expired,
+// otherwise fetches a fresh one from upstream and caches it.
+func (c *CachingClient) FetchRoster(ctx context.Context, allyCode string) (*PlayerRoster, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[allyCode]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.roster, nil
+	}
+
+	roster, err := c.upstream.FetchRoster(ctx, allyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[allyCode] = cacheEntry{roster: roster, expiresAt: time.Now().Add(c.ttl)}
+	c.mu.Unlock()
+
+	return roster, nil
+}
@@ -0,0 +1,29 @@
+// Package swgohclient fetches a player's SWGOH roster from an upstream API
+// so callers can render portraits for characters the caller never
+// manually parameterized themselves.
+package swgohclient
+
+import "context"
+
+// CharacterState is the per-character progression swgoh-portraits needs to
+// render a portrait: gear, relic tier, zeta/omicron counts and level.
+type CharacterState struct {
+	GearLevel  int
+	RelicLevel int
+	Zetas      int
+	Omicrons   int
+	Level      int
+}
+
+// PlayerRoster is a player's full roster, keyed by character id (e.g.
+// "darth_vader") so it can be looked up the same way CharacterRegistry is.
+type PlayerRoster struct {
+	AllyCode   string
+	Characters map[string]CharacterState
+}
+
+// Client fetches a player's roster from an upstream roster API. Implement
+// this to plug in swgoh.gg, swgoh.help, or a test double.
+type Client interface {
+	FetchRoster(ctx context.Context, allyCode string) (*PlayerRoster, error)
+}
@@ -0,0 +1,84 @@
+package swgohclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// defaultSWGOHGGBaseURL is swgoh.gg's public player API.
+const defaultSWGOHGGBaseURL = "https://swgoh.gg/api"
+
+// SWGOHGGClient fetches player rosters from swgoh.gg.
+type SWGOHGGClient struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewSWGOHGGClient returns a client pointed at swgoh.gg's public API using
+// http.DefaultClient.
+func NewSWGOHGGClient() *SWGOHGGClient {
+	return &SWGOHGGClient{
+		BaseURL:    defaultSWGOHGGBaseURL,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// swgohGGUnit mirrors the fields swgoh-portraits needs out of a single
+// "units" entry in swgoh.gg's player response.
+type swgohGGUnit struct {
+	Data struct {
+		BaseID string `json:"base_id"`
+	} `json:"data"`
+	GearLevel int `json:"gear_level"`
+	RelicTier int `json:"relic_tier"`
+	ZetaCount int `json:"zeta_count"`
+	OmiCount  int `json:"omicron_count"`
+	Level     int `json:"level"`
+}
+
+type swgohGGPlayerResponse struct {
+	Units []swgohGGUnit `json:"units"`
+}
+
+// FetchRoster fetches and flattens a player's roster into a PlayerRoster
+// keyed by character id.
+func (c *SWGOHGGClient) FetchRoster(ctx context.Context, allyCode string) (*PlayerRoster, error) {
+	url := fmt.Sprintf("%s/player/%s/", c.BaseURL, allyCode)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("swgohclient: failed to build request for ally code %q: %w", allyCode, err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("swgohclient: failed to reach swgoh.gg for ally code %q: %w", allyCode, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("swgohclient: no player found for ally code %q", allyCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("swgohclient: swgoh.gg returned status %d for ally code %q", resp.StatusCode, allyCode)
+	}
+
+	var raw swgohGGPlayerResponse
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("swgohclient: failed to decode swgoh.gg response for ally code %q: %w", allyCode, err)
+	}
+
+	characters := make(map[string]CharacterState, len(raw.Units))
+	for _, unit := range raw.Units {
+		characters[unit.Data.BaseID] = CharacterState{
+			GearLevel:  unit.GearLevel,
+			RelicLevel: unit.RelicTier,
+			Zetas:      unit.ZetaCount,
+			Omicrons:   unit.OmiCount,
+			Level:      unit.Level,
+		}
+	}
+
+	return &PlayerRoster{AllyCode: allyCode, Characters: characters}, nil
+}
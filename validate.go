@@ -0,0 +1,29 @@
+package main
+
+import "fmt"
+
+// validatePortrait checks portrait's gear/relic/zeta/omicron levels against
+// char's constraints. It's shared by every endpoint that builds a
+// CharacterPortrait from caller-supplied input (createPortraitHandler,
+// rosterHandler) so they can't drift out of sync with each other's rules.
+func validatePortrait(char Character, portrait CharacterPortrait) error {
+	if portrait.GearLevel < 1 || portrait.GearLevel > 13 {
+		return fmt.Errorf("The gear_level must be between 1 and 13")
+	}
+
+	if portrait.GearLevel != 13 && portrait.RelicLevel != 0 {
+		return fmt.Errorf("The relic_level should not be provided if gear_level is not 13")
+	}
+	if portrait.GearLevel == 13 && (portrait.RelicLevel < 1 || portrait.RelicLevel > 9) {
+		return fmt.Errorf("The relic_level must be between 1 and 9")
+	}
+
+	if portrait.Zetas < 0 || portrait.Zetas > char.MaxZetas {
+		return fmt.Errorf("The zeta level must be between 0 and %d for %s", char.MaxZetas, char.Name)
+	}
+	if portrait.Omicrons < 0 || portrait.Omicrons > char.MaxOmicrons {
+		return fmt.Errorf("The omicron level must be between 0 and %d for %s", char.MaxOmicrons, char.Name)
+	}
+
+	return nil
+}